@@ -0,0 +1,84 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dashboardTokenPath returns where the hash of the current bearer token is
+// persisted between runs.
+func dashboardTokenPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".picoclaw", "dashboard.token")
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// rotateToken generates a fresh bearer token, persists its hash to
+// d.tokenPath, and swaps it in as the token every /api/* request must now
+// present.
+func (d *Dashboard) rotateToken() (string, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(d.tokenPath), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(d.tokenPath, []byte(hashToken(token)+"\n"), 0600); err != nil {
+		return "", err
+	}
+	d.mu.Lock()
+	d.authToken = token
+	d.mu.Unlock()
+	return token, nil
+}
+
+// authMiddleware requires a valid bearer token on every /api/* request,
+// taken from the Authorization header or a ?token= query parameter.
+func (d *Dashboard) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		got := r.URL.Query().Get("token")
+		if got == "" {
+			if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
+				got = strings.TrimPrefix(h, "Bearer ")
+			}
+		}
+
+		d.mu.RLock()
+		want := d.authToken
+		d.mu.RUnlock()
+
+		if got == "" || subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(APIResponse{OK: false, Message: "Unauthorized"})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for a freshly created Instance's log sinks.
+const (
+	defaultLogMaxSizeMB  = 10
+	defaultLogMaxBackups = 5
+	defaultLogMaxAgeDays = 14
+)
+
+// LoggingConfig is the user-tunable rotation policy for an instance's
+// on-disk log sinks, shared by its gateway.log and dashboard.log.
+type LoggingConfig struct {
+	MaxSizeMB  int  `json:"max_size_mb"`
+	MaxBackups int  `json:"max_backups"`
+	MaxAgeDays int  `json:"max_age_days"`
+	Compress   bool `json:"compress"`
+}
+
+// logRotator appends lines to a file, rolling it over to a timestamped
+// backup (optionally gzip-compressed) once it grows past MaxSizeMB, and
+// pruning backups beyond MaxBackups or older than MaxAgeDays.
+type logRotator struct {
+	mu   sync.Mutex
+	path string
+	cfg  LoggingConfig
+	file *os.File
+	size int64
+}
+
+func newLogRotator(path string, cfg LoggingConfig) *logRotator {
+	return &logRotator{path: path, cfg: cfg}
+}
+
+func (lr *logRotator) currentPath() string {
+	return lr.path
+}
+
+func (lr *logRotator) reconfigure(cfg LoggingConfig) {
+	lr.mu.Lock()
+	lr.cfg = cfg
+	lr.mu.Unlock()
+}
+
+func (lr *logRotator) configSnapshot() LoggingConfig {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	return lr.cfg
+}
+
+// write appends line to the sink, then rotates once the file has reached
+// the configured size limit. Failures are swallowed — a logging
+// sink going bad shouldn't take down gateway supervision.
+func (lr *logRotator) write(line string) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	if lr.file == nil {
+		if err := lr.openLocked(); err != nil {
+			return
+		}
+	}
+
+	n, err := lr.file.WriteString(line + "\n")
+	if err != nil {
+		return
+	}
+	lr.size += int64(n)
+
+	if lr.cfg.MaxSizeMB > 0 && lr.size >= int64(lr.cfg.MaxSizeMB)*1024*1024 {
+		lr.rotateLocked()
+	}
+}
+
+func (lr *logRotator) openLocked() error {
+	if err := os.MkdirAll(filepath.Dir(lr.path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(lr.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	lr.file = f
+	lr.size = 0
+	if fi, err := f.Stat(); err == nil {
+		lr.size = fi.Size()
+	}
+	return nil
+}
+
+func (lr *logRotator) rotateLocked() {
+	if lr.file != nil {
+		lr.file.Close()
+		lr.file = nil
+	}
+
+	rotated := fmt.Sprintf("%s.%s", lr.path, time.Now().Format("20060102T150405"))
+	if err := os.Rename(lr.path, rotated); err != nil {
+		lr.openLocked()
+		return
+	}
+	if lr.cfg.Compress {
+		if err := gzipFile(rotated); err == nil {
+			rotated += ".gz"
+		}
+	}
+
+	lr.pruneLocked()
+	lr.openLocked()
+}
+
+// pruneLocked removes rotated backups beyond MaxBackups (newest kept) or
+// older than MaxAgeDays, whichever rule is configured.
+func (lr *logRotator) pruneLocked() {
+	dir := filepath.Dir(lr.path)
+	base := filepath.Base(lr.path)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	type backup struct {
+		name string
+		mod  time.Time
+	}
+	var backups []backup
+	for _, e := range entries {
+		if e.Name() == base || !strings.HasPrefix(e.Name(), base+".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{e.Name(), info.ModTime()})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].mod.After(backups[j].mod) })
+
+	now := time.Now()
+	for i, b := range backups {
+		tooMany := lr.cfg.MaxBackups > 0 && i >= lr.cfg.MaxBackups
+		tooOld := lr.cfg.MaxAgeDays > 0 && now.Sub(b.mod) > time.Duration(lr.cfg.MaxAgeDays)*24*time.Hour
+		if tooMany || tooOld {
+			os.Remove(filepath.Join(dir, b.name))
+		}
+	}
+}
+
+func gzipFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	if _, err := gw.Write(data); err != nil {
+		gw.Close()
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// readLogFileLines returns every line in a (possibly gzip-compressed) log
+// file or backup.
+func readLogFileLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// parseLineTime extracts the "[HH:MM:SS]" prefix written by addLogLocked and
+// anchors it to today's date, since on-disk lines carry no date of their
+// own. Good enough for "only lines newer than my last poll" filtering, which
+// is the only thing serveLogsFile's since param is used for.
+func parseLineTime(line string) (time.Time, bool) {
+	if len(line) < 10 || line[0] != '[' || line[9] != ']' {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation("15:04:05", line[1:9], time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local), true
+}
+
+// instanceLogDir returns where an instance's rotated log files live. The
+// default instance keeps the original top-level path for compatibility;
+// named instances get their own directory alongside their config.
+func instanceLogDir(name string) string {
+	home, _ := os.UserHomeDir()
+	if name == defaultInstanceName {
+		return filepath.Join(home, ".picoclaw", "logs")
+	}
+	return filepath.Join(home, ".picoclaw", "instances", name, "logs")
+}
+
+// ── HTTP handlers ───────────────────────────────────────
+
+func (d *Dashboard) handleLogging(w http.ResponseWriter, r *http.Request) {
+	d.serveLogging(w, r, d.defaultInstance())
+}
+
+func (d *Dashboard) serveLogging(w http.ResponseWriter, r *http.Request, inst *Instance) {
+	switch r.Method {
+	case http.MethodGet:
+		jsonResp(w, APIResponse{OK: true, Data: inst.gatewayLog.configSnapshot()})
+
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			jsonResp(w, APIResponse{OK: false, Message: "Failed to read body"})
+			return
+		}
+		var cfg LoggingConfig
+		if err := json.Unmarshal(body, &cfg); err != nil {
+			jsonResp(w, APIResponse{OK: false, Message: fmt.Sprintf("Invalid JSON: %v", err)})
+			return
+		}
+		if cfg.MaxSizeMB <= 0 {
+			jsonResp(w, APIResponse{OK: false, Message: "max_size_mb must be > 0"})
+			return
+		}
+
+		inst.gatewayLog.reconfigure(cfg)
+		inst.dashboardLog.reconfigure(cfg)
+		inst.addLog("Logging policy updated: max_size_mb=%d max_backups=%d max_age_days=%d compress=%v",
+			cfg.MaxSizeMB, cfg.MaxBackups, cfg.MaxAgeDays, cfg.Compress)
+		jsonResp(w, APIResponse{OK: true, Message: "Logging policy updated"})
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (d *Dashboard) handleLogsFile(w http.ResponseWriter, r *http.Request) {
+	d.serveLogsFile(w, r, d.defaultInstance())
+}
+
+// serveLogsFile streams historical gateway log lines from disk — the
+// current file plus any rotated (including gzip) backups — so the UI can
+// scroll back past the in-memory ring.
+func (d *Dashboard) serveLogsFile(w http.ResponseWriter, r *http.Request, inst *Instance) {
+	since := int64(0)
+	if s := r.URL.Query().Get("since"); s != "" {
+		fmt.Sscanf(s, "%d", &since)
+	}
+	limit := 500
+	if s := r.URL.Query().Get("limit"); s != "" {
+		fmt.Sscanf(s, "%d", &limit)
+	}
+	if limit <= 0 {
+		limit = 500
+	}
+
+	logPath := inst.gatewayLog.currentPath()
+	dir := filepath.Dir(logPath)
+	base := filepath.Base(logPath)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		jsonResp(w, APIResponse{OK: true, Data: []string{}})
+		return
+	}
+
+	type candidate struct {
+		path string
+		mod  time.Time
+	}
+	var files []candidate
+	for _, e := range entries {
+		name := e.Name()
+		if name != base && !strings.HasPrefix(name, base+".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, candidate{filepath.Join(dir, name), info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].mod.Before(files[j].mod) })
+
+	var all []string
+	for _, f := range files {
+		lines, err := readLogFileLines(f.path)
+		if err != nil {
+			continue
+		}
+		all = append(all, lines...)
+	}
+
+	if since > 0 {
+		cutoff := time.Unix(since, 0)
+		filtered := all[:0]
+		for _, line := range all {
+			if t, ok := parseLineTime(line); ok && t.Before(cutoff) {
+				continue
+			}
+			filtered = append(filtered, line)
+		}
+		all = filtered
+	}
+
+	if len(all) > limit {
+		all = all[len(all)-limit:]
+	}
+
+	jsonResp(w, APIResponse{OK: true, Data: all})
+}
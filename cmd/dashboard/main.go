@@ -13,6 +13,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -23,28 +24,222 @@ var indexHTML []byte
 
 const maxLogLines = 3000
 
+// subscriberBuffer is the per-client backlog before a lagging stream is disconnected.
+const subscriberBuffer = 256
+
+// defaultInstanceName is the gateway instance backing the legacy top-level
+// /api/* routes, so single-instance setups keep working unchanged.
+const defaultInstanceName = "default"
+
+// defaultHealthPortBase is the first health port tried when auto-assigning
+// one to a newly created instance.
+const defaultHealthPortBase = 18790
+
+// RestartPolicy controls whether the supervisor re-launches the gateway
+// after it exits, mirroring supervisord's semantics.
+type RestartPolicy string
+
+const (
+	RestartNever     RestartPolicy = "never"
+	RestartOnFailure RestartPolicy = "on-failure"
+	RestartAlways    RestartPolicy = "always"
+)
+
+// GatewayState tracks the supervisor's view of the child process, surfaced
+// in StatusResponse so the UI can show retry/backoff progress.
+type GatewayState string
+
+const (
+	StateStopped  GatewayState = "stopped"
+	StateStarting GatewayState = "starting"
+	StateRunning  GatewayState = "running"
+	StateBackoff  GatewayState = "backoff"
+	StateFatal    GatewayState = "fatal"
+	StateStopping GatewayState = "stopping"
+)
+
+// defaultStartSeconds is how long a gateway must stay up before the
+// supervisor considers the start successful and resets the retry counter.
+const defaultStartSeconds = 5 * time.Second
+
+// defaultBackoffMax caps the exponential backoff between restart attempts.
+const defaultBackoffMax = 60 * time.Second
+
+// configPollInterval is how often the config watcher stats the config file.
+const configPollInterval = 1 * time.Second
+
+// configDebounce is how long the watcher waits after seeing a changed
+// mtime before acting, so the write-then-rename of the ".bak" backup
+// doesn't trigger a spurious reload mid-write.
+const configDebounce = 500 * time.Millisecond
+
+// Instance is one supervised gateway process: its own config file, health
+// port, log ring and restart policy. Dashboard can hold many of these so a
+// single dashboard can run e.g. a staging and a prod gateway side by side.
+type Instance struct {
+	mu          sync.RWMutex
+	name        string
+	binaryPath  string
+	configPath  string
+	healthPort  int
+	process     *exec.Cmd
+	running     bool
+	startTime   time.Time
+	logs        []string
+	cancel      context.CancelFunc
+	subscribers map[chan string]struct{}
+
+	restartPolicy RestartPolicy
+	startRetries  int
+	startSeconds  time.Duration
+	backoffMax    time.Duration
+
+	state       GatewayState
+	retryCount  int
+	nextAttempt time.Time
+	manualStop  bool
+	stopBackoff chan struct{}
+
+	gatewayLog   *logRotator
+	dashboardLog *logRotator
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newInstance(name, binaryPath, configPath string, healthPort int) *Instance {
+	logDir := instanceLogDir(name)
+	logCfg := LoggingConfig{
+		MaxSizeMB:  defaultLogMaxSizeMB,
+		MaxBackups: defaultLogMaxBackups,
+		MaxAgeDays: defaultLogMaxAgeDays,
+		Compress:   true,
+	}
+	return &Instance{
+		name:          name,
+		binaryPath:    binaryPath,
+		configPath:    configPath,
+		healthPort:    healthPort,
+		logs:          make([]string, 0, maxLogLines),
+		subscribers:   make(map[chan string]struct{}),
+		restartPolicy: RestartNever,
+		startRetries:  5,
+		startSeconds:  defaultStartSeconds,
+		backoffMax:    defaultBackoffMax,
+		state:         StateStopped,
+		gatewayLog:    newLogRotator(filepath.Join(logDir, "gateway.log"), logCfg),
+		dashboardLog:  newLogRotator(filepath.Join(logDir, "dashboard.log"), logCfg),
+		done:          make(chan struct{}),
+	}
+}
+
+// Dashboard manages one or more gateway Instances and serves the HTTP API
+// and embedded UI used to control them.
 type Dashboard struct {
 	mu         sync.RWMutex
-	process    *exec.Cmd
-	running    bool
-	startTime  time.Time
-	logs       []string
+	instances  map[string]*Instance
 	binaryPath string
-	configPath string
-	cancel     context.CancelFunc
-	healthPort int
+
+	authToken string
+	tokenPath string
+}
+
+func (d *Dashboard) instance(name string) (*Instance, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	inst, ok := d.instances[name]
+	return inst, ok
+}
+
+func (d *Dashboard) defaultInstance() *Instance {
+	inst, _ := d.instance(defaultInstanceName)
+	return inst
+}
+
+func (d *Dashboard) addInstance(inst *Instance) {
+	d.mu.Lock()
+	d.instances[inst.name] = inst
+	d.mu.Unlock()
+}
+
+func (d *Dashboard) removeInstance(name string) {
+	d.mu.Lock()
+	delete(d.instances, name)
+	d.mu.Unlock()
+}
+
+func (d *Dashboard) listInstanceNames() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	names := make([]string, 0, len(d.instances))
+	for n := range d.instances {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// assignHealthPort picks the first free port at or after
+// defaultHealthPortBase that no existing instance is already using.
+func (d *Dashboard) assignHealthPort() int {
+	d.mu.RLock()
+	used := make(map[int]bool, len(d.instances))
+	for _, inst := range d.instances {
+		used[inst.healthPortSnapshot()] = true
+	}
+	d.mu.RUnlock()
+
+	port := defaultHealthPortBase
+	for used[port] {
+		port++
+	}
+	return port
+}
+
+func instanceConfigPath(name string) string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".picoclaw", "instances", name, "config.json")
+}
+
+// validInstanceName keeps instance names safe to use as path segments and
+// reserves "default" for the built-in instance.
+func validInstanceName(name string) bool {
+	if name == "" || name == defaultInstanceName {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
 }
 
 type StatusResponse struct {
-	GatewayRunning bool        `json:"gateway_running"`
-	GatewayPID     int         `json:"gateway_pid"`
-	Uptime         string      `json:"uptime"`
-	ConfigExists   bool        `json:"config_exists"`
-	BinaryExists   bool        `json:"binary_exists"`
-	BinaryPath     string      `json:"binary_path"`
-	ConfigPath     string      `json:"config_path"`
-	HealthPort     int         `json:"health_port"`
-	Health         *HealthInfo `json:"health"`
+	Name           string       `json:"name,omitempty"`
+	GatewayRunning bool         `json:"gateway_running"`
+	GatewayPID     int          `json:"gateway_pid"`
+	Uptime         string       `json:"uptime"`
+	ConfigExists   bool         `json:"config_exists"`
+	BinaryExists   bool         `json:"binary_exists"`
+	BinaryPath     string       `json:"binary_path"`
+	ConfigPath     string       `json:"config_path"`
+	HealthPort     int          `json:"health_port"`
+	Health         *HealthInfo  `json:"health"`
+	State          GatewayState `json:"state"`
+	RetryCount     int          `json:"retry_count"`
+	NextAttempt    *time.Time   `json:"next_attempt,omitempty"`
+}
+
+// SupervisorConfig is the user-tunable half of the restart supervisor,
+// exposed via GET/PUT /api/supervisor.
+type SupervisorConfig struct {
+	RestartPolicy RestartPolicy `json:"restart_policy"`
+	StartRetries  int           `json:"start_retries"`
+	StartSeconds  float64       `json:"start_seconds"`
+	BackoffMax    float64       `json:"backoff_max"`
 }
 
 type HealthInfo struct {
@@ -60,6 +255,7 @@ type APIResponse struct {
 
 func main() {
 	port := 18080
+	var tlsCert, tlsKey string
 
 	args := os.Args[1:]
 	for i := 0; i < len(args); i++ {
@@ -69,27 +265,54 @@ func main() {
 				fmt.Sscanf(args[i+1], "%d", &port)
 				i++
 			}
+		case "--tls-cert":
+			if i+1 < len(args) {
+				tlsCert = args[i+1]
+				i++
+			}
+		case "--tls-key":
+			if i+1 < len(args) {
+				tlsKey = args[i+1]
+				i++
+			}
 		}
 	}
 
+	if tlsCert == "" || tlsKey == "" {
+		tlsCert, tlsKey = defaultCertPaths()
+	}
+	if err := ensureSelfSignedCert(tlsCert, tlsKey); err != nil {
+		fmt.Printf("Error: failed to prepare TLS certificate: %v\n", err)
+		os.Exit(1)
+	}
+
 	binaryPath := findBinary()
 	configPath := getConfigPath()
+	healthPort := defaultHealthPortBase
 
-	d := &Dashboard{
-		binaryPath: binaryPath,
-		configPath: configPath,
-		logs:       make([]string, 0, maxLogLines),
-		healthPort: 18790,
-	}
-
-	if cfg, err := d.readConfigMap(); err == nil {
+	if cfg, err := readConfigMap(configPath); err == nil {
 		if gw, ok := cfg["gateway"].(map[string]interface{}); ok {
 			if p, ok := gw["port"].(float64); ok {
-				d.healthPort = int(p)
+				healthPort = int(p)
 			}
 		}
 	}
 
+	d := &Dashboard{
+		binaryPath: binaryPath,
+		instances:  make(map[string]*Instance),
+		tokenPath:  dashboardTokenPath(),
+	}
+	def := newInstance(defaultInstanceName, binaryPath, configPath, healthPort)
+	d.addInstance(def)
+	go def.watchConfig()
+
+	token, err := d.rotateToken()
+	if err != nil {
+		fmt.Printf("Error: failed to generate auth token: %v\n", err)
+		os.Exit(1)
+	}
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", d.handleIndex)
 	mux.HandleFunc("/api/status", d.handleStatus)
@@ -99,22 +322,31 @@ func main() {
 	mux.HandleFunc("/api/stop", d.handleStop)
 	mux.HandleFunc("/api/restart", d.handleRestart)
 	mux.HandleFunc("/api/logs", d.handleLogs)
+	mux.HandleFunc("/api/logs/stream", d.handleLogsStream)
+	mux.HandleFunc("/api/supervisor", d.handleSupervisor)
+	mux.HandleFunc("/api/instances", d.handleInstances)
+	mux.HandleFunc("/api/instances/", d.handleInstanceRoute)
+	mux.HandleFunc("/api/auth/rotate", d.handleAuthRotate)
+	mux.HandleFunc("/api/logging", d.handleLogging)
+	mux.HandleFunc("/api/logs/file", d.handleLogsFile)
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", port),
-		Handler: mux,
+		Handler: d.authMiddleware(mux),
 	}
 
-	d.addLog("Dashboard started on port %d", port)
-	d.addLog("Binary: %s (exists: %v)", binaryPath, fileExists(binaryPath))
-	d.addLog("Config: %s (exists: %v)", configPath, fileExists(configPath))
+	def.addLog("Dashboard started on port %d", port)
+	def.addLog("Binary: %s (exists: %v)", binaryPath, fileExists(binaryPath))
+	def.addLog("Config: %s (exists: %v)", configPath, fileExists(configPath))
+
+	launchURL := fmt.Sprintf("https://localhost:%d/?token=%s", port, token)
 
 	go func() {
 		time.Sleep(600 * time.Millisecond)
-		openBrowser(fmt.Sprintf("http://localhost:%d", port))
+		openBrowser(launchURL)
 	}()
 
-	fmt.Printf("PicoClaw Dashboard: http://localhost:%d\n", port)
+	fmt.Printf("PicoClaw Dashboard: %s\n", launchURL)
 	fmt.Println("Press Ctrl+C to stop")
 
 	sigChan := make(chan os.Signal, 1)
@@ -122,11 +354,15 @@ func main() {
 	go func() {
 		<-sigChan
 		fmt.Println("\nShutting down...")
-		d.stopGateway()
+		for _, name := range d.listInstanceNames() {
+			if inst, ok := d.instance(name); ok {
+				inst.stopGateway()
+			}
+		}
 		server.Shutdown(context.Background())
 	}()
 
-	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+	if err := server.ListenAndServeTLS(tlsCert, tlsKey); err != http.ErrServerClosed {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -140,38 +376,203 @@ func (d *Dashboard) handleIndex(w http.ResponseWriter, r *http.Request) {
 }
 
 func (d *Dashboard) handleStatus(w http.ResponseWriter, r *http.Request) {
-	d.mu.RLock()
-	running := d.running
-	var pid int
-	var uptime string
-	if running && d.process != nil && d.process.Process != nil {
-		pid = d.process.Process.Pid
-		uptime = time.Since(d.startTime).Truncate(time.Second).String()
+	jsonResp(w, APIResponse{OK: true, Data: d.defaultInstance().status()})
+}
+
+func (d *Dashboard) handleConfig(w http.ResponseWriter, r *http.Request) {
+	d.serveConfig(w, r, d.defaultInstance())
+}
+
+func (d *Dashboard) handleValidate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
 	}
-	d.mu.RUnlock()
 
-	status := StatusResponse{
-		GatewayRunning: running,
-		GatewayPID:     pid,
-		Uptime:         uptime,
-		ConfigExists:   fileExists(d.configPath),
-		BinaryExists:   fileExists(d.binaryPath),
-		BinaryPath:     d.binaryPath,
-		ConfigPath:     d.configPath,
-		HealthPort:     d.healthPort,
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		jsonResp(w, APIResponse{OK: false, Message: "Failed to read body"})
+		return
 	}
 
-	if running {
-		status.Health = d.checkHealth()
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		jsonResp(w, APIResponse{OK: false, Message: fmt.Sprintf("Invalid JSON: %v", err)})
+		return
 	}
 
-	jsonResp(w, APIResponse{OK: true, Data: status})
+	warnings := validateConfig(parsed)
+
+	if len(warnings) > 0 {
+		jsonResp(w, APIResponse{OK: true, Message: "Valid JSON with warnings", Data: warnings})
+	} else {
+		jsonResp(w, APIResponse{OK: true, Message: "Configuration looks good!"})
+	}
 }
 
-func (d *Dashboard) handleConfig(w http.ResponseWriter, r *http.Request) {
+func (d *Dashboard) handleStart(w http.ResponseWriter, r *http.Request) {
+	d.serveStart(w, r, d.defaultInstance())
+}
+
+func (d *Dashboard) handleStop(w http.ResponseWriter, r *http.Request) {
+	d.serveStop(w, r, d.defaultInstance())
+}
+
+func (d *Dashboard) handleRestart(w http.ResponseWriter, r *http.Request) {
+	d.serveRestart(w, r, d.defaultInstance())
+}
+
+func (d *Dashboard) handleSupervisor(w http.ResponseWriter, r *http.Request) {
+	d.serveSupervisor(w, r, d.defaultInstance())
+}
+
+func (d *Dashboard) handleLogs(w http.ResponseWriter, r *http.Request) {
+	d.serveLogs(w, r, d.defaultInstance())
+}
+
+func (d *Dashboard) handleLogsStream(w http.ResponseWriter, r *http.Request) {
+	d.serveLogsStream(w, r, d.defaultInstance())
+}
+
+// handleAuthRotate regenerates the dashboard's bearer token, invalidating
+// the previous one immediately.
+func (d *Dashboard) handleAuthRotate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token, err := d.rotateToken()
+	if err != nil {
+		jsonResp(w, APIResponse{OK: false, Message: err.Error()})
+		return
+	}
+	jsonResp(w, APIResponse{OK: true, Message: "Token rotated", Data: map[string]string{"token": token}})
+}
+
+// handleInstances serves the instance collection: GET lists every instance
+// with its current status, POST creates a new one with its own config file
+// and auto-assigned health port.
+func (d *Dashboard) handleInstances(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		names := d.listInstanceNames()
+		statuses := make([]StatusResponse, 0, len(names))
+		for _, name := range names {
+			if inst, ok := d.instance(name); ok {
+				statuses = append(statuses, inst.status())
+			}
+		}
+		jsonResp(w, APIResponse{OK: true, Data: statuses})
+
+	case http.MethodPost:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			jsonResp(w, APIResponse{OK: false, Message: "Failed to read body"})
+			return
+		}
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			jsonResp(w, APIResponse{OK: false, Message: fmt.Sprintf("Invalid JSON: %v", err)})
+			return
+		}
+		if !validInstanceName(req.Name) {
+			jsonResp(w, APIResponse{OK: false, Message: "Invalid instance name — use letters, digits, '-' or '_', and not 'default'"})
+			return
+		}
+		if _, exists := d.instance(req.Name); exists {
+			jsonResp(w, APIResponse{OK: false, Message: fmt.Sprintf("Instance %q already exists", req.Name)})
+			return
+		}
+
+		configPath := instanceConfigPath(req.Name)
+		os.MkdirAll(filepath.Dir(configPath), 0755)
+		healthPort := d.assignHealthPort()
+
+		inst := newInstance(req.Name, d.binaryPath, configPath, healthPort)
+		d.addInstance(inst)
+		go inst.watchConfig()
+		inst.addLog("Instance %q created (config: %s, health port: %d)", req.Name, configPath, healthPort)
+
+		jsonResp(w, APIResponse{OK: true, Message: "Instance created", Data: inst.status()})
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleInstanceRoute dispatches /api/instances/{name}[/action] to the
+// handler mirroring its single-gateway counterpart.
+func (d *Dashboard) handleInstanceRoute(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/instances/"), "/")
+	if path == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	parts := strings.SplitN(path, "/", 2)
+	inst, ok := d.instance(parts[0])
+	if !ok {
+		jsonResp(w, APIResponse{OK: false, Message: fmt.Sprintf("Instance %q not found", parts[0])})
+		return
+	}
+
+	if len(parts) == 1 {
+		d.serveInstance(w, r, inst)
+		return
+	}
+
+	switch parts[1] {
+	case "start":
+		d.serveStart(w, r, inst)
+	case "stop":
+		d.serveStop(w, r, inst)
+	case "restart":
+		d.serveRestart(w, r, inst)
+	case "logs":
+		d.serveLogs(w, r, inst)
+	case "logs/stream":
+		d.serveLogsStream(w, r, inst)
+	case "logs/file":
+		d.serveLogsFile(w, r, inst)
+	case "config":
+		d.serveConfig(w, r, inst)
+	case "supervisor":
+		d.serveSupervisor(w, r, inst)
+	case "logging":
+		d.serveLogging(w, r, inst)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveInstance handles GET (status) and DELETE (teardown) on
+// /api/instances/{name}.
+func (d *Dashboard) serveInstance(w http.ResponseWriter, r *http.Request, inst *Instance) {
 	switch r.Method {
 	case http.MethodGet:
-		data, err := os.ReadFile(d.configPath)
+		jsonResp(w, APIResponse{OK: true, Data: inst.status()})
+
+	case http.MethodDelete:
+		if inst.name == defaultInstanceName {
+			jsonResp(w, APIResponse{OK: false, Message: "Cannot delete the default instance"})
+			return
+		}
+		inst.stopGateway()
+		inst.closeOnce.Do(func() { close(inst.done) })
+		d.removeInstance(inst.name)
+		jsonResp(w, APIResponse{OK: true, Message: fmt.Sprintf("Instance %q removed", inst.name)})
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (d *Dashboard) serveConfig(w http.ResponseWriter, r *http.Request, inst *Instance) {
+	switch r.Method {
+	case http.MethodGet:
+		data, err := os.ReadFile(inst.configPath)
 		if err != nil {
 			if os.IsNotExist(err) {
 				jsonResp(w, APIResponse{OK: false, Message: "Config not found. Use 'Create Default' to initialize."})
@@ -198,32 +599,32 @@ func (d *Dashboard) handleConfig(w http.ResponseWriter, r *http.Request) {
 
 		pretty, _ := json.MarshalIndent(parsed, "", "  ")
 
-		if fileExists(d.configPath) {
-			os.Rename(d.configPath, d.configPath+".bak")
+		if fileExists(inst.configPath) {
+			os.Rename(inst.configPath, inst.configPath+".bak")
 		}
-		os.MkdirAll(filepath.Dir(d.configPath), 0755)
+		os.MkdirAll(filepath.Dir(inst.configPath), 0755)
 
-		if err := os.WriteFile(d.configPath, append(pretty, '\n'), 0644); err != nil {
+		if err := os.WriteFile(inst.configPath, append(pretty, '\n'), 0644); err != nil {
 			jsonResp(w, APIResponse{OK: false, Message: fmt.Sprintf("Failed to save: %v", err)})
 			return
 		}
 
-		d.addLog("Config saved to %s", d.configPath)
+		inst.addLog("Config saved to %s", inst.configPath)
 		jsonResp(w, APIResponse{OK: true, Message: "Configuration saved"})
 
 	case http.MethodPost:
-		if !fileExists(d.binaryPath) {
+		if !fileExists(inst.binaryPath) {
 			jsonResp(w, APIResponse{OK: false, Message: "PicoClaw binary not found"})
 			return
 		}
-		cmd := exec.Command(d.binaryPath, "onboard")
+		cmd := exec.Command(inst.binaryPath, "onboard")
 		cmd.Stdin = strings.NewReader("y\n")
 		output, err := cmd.CombinedOutput()
 		if err != nil {
 			jsonResp(w, APIResponse{OK: false, Message: fmt.Sprintf("Onboard failed: %v\n%s", err, string(output))})
 			return
 		}
-		d.addLog("Onboard completed: %s", strings.TrimSpace(string(output)))
+		inst.addLog("Onboard completed: %s", strings.TrimSpace(string(output)))
 		jsonResp(w, APIResponse{OK: true, Message: "Default configuration created"})
 
 	default:
@@ -231,99 +632,198 @@ func (d *Dashboard) handleConfig(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (d *Dashboard) handleValidate(w http.ResponseWriter, r *http.Request) {
+func (d *Dashboard) serveStart(w http.ResponseWriter, r *http.Request, inst *Instance) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
-
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		jsonResp(w, APIResponse{OK: false, Message: "Failed to read body"})
-		return
-	}
-
-	var parsed map[string]interface{}
-	if err := json.Unmarshal(body, &parsed); err != nil {
-		jsonResp(w, APIResponse{OK: false, Message: fmt.Sprintf("Invalid JSON: %v", err)})
+	inst.mu.Lock()
+	inst.manualStop = false
+	inst.retryCount = 0
+	inst.cancelPendingBackoffLocked()
+	inst.mu.Unlock()
+	if err := inst.startGateway(); err != nil {
+		jsonResp(w, APIResponse{OK: false, Message: err.Error()})
 		return
 	}
-
-	warnings := validateConfig(parsed)
-
-	if len(warnings) > 0 {
-		jsonResp(w, APIResponse{OK: true, Message: "Valid JSON with warnings", Data: warnings})
-	} else {
-		jsonResp(w, APIResponse{OK: true, Message: "Configuration looks good!"})
-	}
+	jsonResp(w, APIResponse{OK: true, Message: "Gateway started"})
 }
 
-func (d *Dashboard) handleStart(w http.ResponseWriter, r *http.Request) {
+func (d *Dashboard) serveStop(w http.ResponseWriter, r *http.Request, inst *Instance) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	if err := d.startGateway(); err != nil {
+	if err := inst.stopGateway(); err != nil {
 		jsonResp(w, APIResponse{OK: false, Message: err.Error()})
 		return
 	}
-	jsonResp(w, APIResponse{OK: true, Message: "Gateway started"})
+	jsonResp(w, APIResponse{OK: true, Message: "Gateway stop signal sent"})
 }
 
-func (d *Dashboard) handleStop(w http.ResponseWriter, r *http.Request) {
+func (d *Dashboard) serveRestart(w http.ResponseWriter, r *http.Request, inst *Instance) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	if err := d.stopGateway(); err != nil {
+	inst.stopGateway()
+	time.Sleep(1500 * time.Millisecond)
+	inst.mu.Lock()
+	inst.manualStop = false
+	inst.retryCount = 0
+	inst.mu.Unlock()
+	if err := inst.startGateway(); err != nil {
 		jsonResp(w, APIResponse{OK: false, Message: err.Error()})
 		return
 	}
-	jsonResp(w, APIResponse{OK: true, Message: "Gateway stop signal sent"})
+	jsonResp(w, APIResponse{OK: true, Message: "Gateway restarted"})
 }
 
-func (d *Dashboard) handleRestart(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
+func (d *Dashboard) serveSupervisor(w http.ResponseWriter, r *http.Request, inst *Instance) {
+	switch r.Method {
+	case http.MethodGet:
+		inst.mu.RLock()
+		cfg := SupervisorConfig{
+			RestartPolicy: inst.restartPolicy,
+			StartRetries:  inst.startRetries,
+			StartSeconds:  inst.startSeconds.Seconds(),
+			BackoffMax:    inst.backoffMax.Seconds(),
+		}
+		inst.mu.RUnlock()
+		jsonResp(w, APIResponse{OK: true, Data: cfg})
+
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			jsonResp(w, APIResponse{OK: false, Message: "Failed to read body"})
+			return
+		}
+		var cfg SupervisorConfig
+		if err := json.Unmarshal(body, &cfg); err != nil {
+			jsonResp(w, APIResponse{OK: false, Message: fmt.Sprintf("Invalid JSON: %v", err)})
+			return
+		}
+		switch cfg.RestartPolicy {
+		case RestartNever, RestartOnFailure, RestartAlways:
+		default:
+			jsonResp(w, APIResponse{OK: false, Message: fmt.Sprintf("Invalid restart_policy: %q", cfg.RestartPolicy)})
+			return
+		}
+		if cfg.StartRetries < 0 || cfg.StartSeconds <= 0 || cfg.BackoffMax <= 0 {
+			jsonResp(w, APIResponse{OK: false, Message: "start_retries must be >= 0 and start_seconds/backoff_max must be > 0"})
+			return
+		}
+
+		inst.mu.Lock()
+		inst.restartPolicy = cfg.RestartPolicy
+		inst.startRetries = cfg.StartRetries
+		inst.startSeconds = time.Duration(cfg.StartSeconds * float64(time.Second))
+		inst.backoffMax = time.Duration(cfg.BackoffMax * float64(time.Second))
+		inst.retryCount = 0
+		inst.addLogLocked("Supervisor policy updated: restart=%s retries=%d start_seconds=%.0f backoff_max=%.0f",
+			cfg.RestartPolicy, cfg.StartRetries, cfg.StartSeconds, cfg.BackoffMax)
+		inst.mu.Unlock()
+		jsonResp(w, APIResponse{OK: true, Message: "Supervisor policy updated"})
+
+	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
 	}
-	d.stopGateway()
-	time.Sleep(1500 * time.Millisecond)
-	if err := d.startGateway(); err != nil {
-		jsonResp(w, APIResponse{OK: false, Message: err.Error()})
+}
+
+func (d *Dashboard) serveLogs(w http.ResponseWriter, r *http.Request, inst *Instance) {
+	jsonResp(w, APIResponse{OK: true, Data: inst.logsSnapshot()})
+}
+
+// serveLogsStream pushes log lines to the client as Server-Sent Events,
+// replaying the in-memory backlog before switching to live mode. A slow
+// reader that can't keep up is disconnected rather than stalling the
+// writer goroutines in captureOutput.
+func (d *Dashboard) serveLogsStream(w http.ResponseWriter, r *http.Request, inst *Instance) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
 		return
 	}
-	jsonResp(w, APIResponse{OK: true, Message: "Gateway restarted"})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := inst.subscribe()
+	defer inst.unsubscribe(ch)
+
+	for _, line := range inst.logsSnapshot() {
+		fmt.Fprintf(w, "data: %s\n\n", line)
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case line, ok := <-ch:
+			if !ok {
+				fmt.Fprintf(w, "data: log stream lagging, disconnecting\n\n")
+				flusher.Flush()
+				return
+			}
+			fmt.Fprintf(w, "data: %s\n\n", line)
+			flusher.Flush()
+		}
+	}
 }
 
-func (d *Dashboard) handleLogs(w http.ResponseWriter, r *http.Request) {
-	d.mu.RLock()
-	logs := make([]string, len(d.logs))
-	copy(logs, d.logs)
-	d.mu.RUnlock()
-	jsonResp(w, APIResponse{OK: true, Data: logs})
+// ── Instance: pub/sub ───────────────────────────────────
+
+// subscribe registers a new buffered channel for live log fan-out.
+func (inst *Instance) subscribe() chan string {
+	ch := make(chan string, subscriberBuffer)
+	inst.mu.Lock()
+	inst.subscribers[ch] = struct{}{}
+	inst.mu.Unlock()
+	return ch
 }
 
-// ── Process Management ──────────────────────────────────
+func (inst *Instance) unsubscribe(ch chan string) {
+	inst.mu.Lock()
+	delete(inst.subscribers, ch)
+	inst.mu.Unlock()
+}
 
-func (d *Dashboard) startGateway() error {
-	d.mu.Lock()
-	defer d.mu.Unlock()
+// broadcastLocked fans a log line out to every subscriber. Callers must
+// hold inst.mu. A subscriber whose buffer is full is dropped rather than
+// blocking the caller.
+func (inst *Instance) broadcastLocked(line string) {
+	for ch := range inst.subscribers {
+		select {
+		case ch <- line:
+		default:
+			close(ch)
+			delete(inst.subscribers, ch)
+		}
+	}
+}
 
-	if d.running {
+// ── Instance: process management ────────────────────────
+
+func (inst *Instance) startGateway() error {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+
+	if inst.running {
 		return fmt.Errorf("gateway is already running")
 	}
-	if !fileExists(d.binaryPath) {
-		return fmt.Errorf("binary not found: %s", d.binaryPath)
+	if !fileExists(inst.binaryPath) {
+		return fmt.Errorf("binary not found: %s", inst.binaryPath)
 	}
-	if !fileExists(d.configPath) {
+	if !fileExists(inst.configPath) {
 		return fmt.Errorf("config not found — create default config first")
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
-	d.cancel = cancel
+	inst.cancel = cancel
 
-	cmd := exec.CommandContext(ctx, d.binaryPath, "gateway")
+	cmd := exec.CommandContext(ctx, inst.binaryPath, "gateway", "--config", inst.configPath)
 
 	stdout, _ := cmd.StdoutPipe()
 	stderr, _ := cmd.StderrPipe()
@@ -333,99 +833,337 @@ func (d *Dashboard) startGateway() error {
 		return fmt.Errorf("failed to start: %v", err)
 	}
 
-	d.process = cmd
-	d.running = true
-	d.startTime = time.Now()
-	d.addLogLocked("Gateway started (PID: %d)", cmd.Process.Pid)
+	inst.process = cmd
+	inst.running = true
+	inst.state = StateRunning
+	inst.startTime = time.Now()
+	inst.addLogLocked("Gateway started (PID: %d)", cmd.Process.Pid)
 
-	go d.captureOutput(stdout)
-	go d.captureOutput(stderr)
+	go inst.captureOutput(stdout)
+	go inst.captureOutput(stderr)
 
-	go func() {
-		err := cmd.Wait()
-		d.mu.Lock()
-		d.running = false
-		d.process = nil
-		d.cancel = nil
-		d.mu.Unlock()
-		if err != nil {
-			d.addLog("Gateway exited: %v", err)
-		} else {
-			d.addLog("Gateway stopped")
-		}
-	}()
+	go inst.superviseExit(cmd)
 
 	return nil
 }
 
-func (d *Dashboard) stopGateway() error {
-	d.mu.Lock()
-	defer d.mu.Unlock()
+// superviseExit waits for the gateway process to exit and, depending on the
+// configured RestartPolicy, either settles into Stopped/Fatal or re-invokes
+// startGateway after an exponential backoff.
+func (inst *Instance) superviseExit(cmd *exec.Cmd) {
+	err := cmd.Wait()
+
+	inst.mu.Lock()
+	ranFor := time.Since(inst.startTime)
+	inst.running = false
+	inst.process = nil
+	inst.cancel = nil
+	if err != nil {
+		inst.addLogLocked("Gateway exited: %v", err)
+	} else {
+		inst.addLogLocked("Gateway stopped")
+	}
+
+	if inst.manualStop {
+		inst.state = StateStopped
+		inst.retryCount = 0
+		inst.mu.Unlock()
+		return
+	}
+
+	if ranFor >= inst.startSeconds {
+		inst.retryCount = 0
+	} else {
+		inst.retryCount++
+	}
 
-	if !d.running || d.process == nil {
+	policy := inst.restartPolicy
+	shouldRestart := policy == RestartAlways || (policy == RestartOnFailure && err != nil)
+
+	if !shouldRestart {
+		inst.state = StateStopped
+		inst.mu.Unlock()
+		return
+	}
+
+	if inst.retryCount > inst.startRetries {
+		inst.state = StateFatal
+		inst.addLogLocked("Supervisor: retries exhausted (%d), giving up", inst.startRetries)
+		inst.mu.Unlock()
+		return
+	}
+
+	backoff := computeBackoff(inst.retryCount, inst.backoffMax)
+	inst.state = StateBackoff
+	inst.nextAttempt = time.Now().Add(backoff)
+	stop := make(chan struct{})
+	inst.stopBackoff = stop
+	attempt, maxRetries := inst.retryCount, inst.startRetries
+	inst.addLogLocked("Supervisor: restarting in %s (attempt %d/%d)", backoff.Truncate(time.Second), attempt, maxRetries)
+	inst.mu.Unlock()
+
+	select {
+	case <-time.After(backoff):
+	case <-stop:
+		return
+	}
+
+	inst.mu.Lock()
+	inst.stopBackoff = nil
+	inst.mu.Unlock()
+
+	if err := inst.startGateway(); err != nil {
+		inst.mu.Lock()
+		inst.state = StateFatal
+		inst.addLogLocked("Supervisor: restart failed: %v", err)
+		inst.mu.Unlock()
+	}
+}
+
+// computeBackoff returns 2^(attempt-1) seconds, capped at max.
+func computeBackoff(attempt int, max time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > 30 {
+		attempt = 30
+	}
+	backoff := time.Second * time.Duration(uint64(1)<<uint(attempt-1))
+	if backoff > max {
+		backoff = max
+	}
+	return backoff
+}
+
+// cancelPendingBackoffLocked wakes a superviseExit goroutine that's sleeping
+// out a restart backoff, so it returns instead of eventually re-invoking
+// startGateway out from under a start/stop that already happened. Callers
+// must hold inst.mu.
+func (inst *Instance) cancelPendingBackoffLocked() {
+	if inst.stopBackoff != nil {
+		close(inst.stopBackoff)
+		inst.stopBackoff = nil
+	}
+}
+
+func (inst *Instance) stopGateway() error {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+
+	if inst.state == StateBackoff {
+		inst.manualStop = true
+		inst.state = StateStopped
+		inst.retryCount = 0
+		inst.cancelPendingBackoffLocked()
+		inst.addLogLocked("Cancelled pending restart")
+		return nil
+	}
+
+	if !inst.running || inst.process == nil {
 		return fmt.Errorf("gateway is not running")
 	}
 
-	d.addLogLocked("Stopping gateway...")
+	inst.manualStop = true
+	inst.state = StateStopping
+	inst.addLogLocked("Stopping gateway...")
 
-	if d.cancel != nil {
-		d.cancel()
+	if inst.cancel != nil {
+		inst.cancel()
 	}
 
 	if runtime.GOOS == "windows" {
-		d.process.Process.Kill()
+		inst.process.Process.Kill()
 	} else {
-		d.process.Process.Signal(os.Interrupt)
+		inst.process.Process.Signal(os.Interrupt)
 		go func(p *os.Process) {
 			time.Sleep(5 * time.Second)
 			p.Kill()
-		}(d.process.Process)
+		}(inst.process.Process)
 	}
 
 	return nil
 }
 
-func (d *Dashboard) captureOutput(r io.Reader) {
+func (inst *Instance) isRunning() bool {
+	inst.mu.RLock()
+	defer inst.mu.RUnlock()
+	return inst.running
+}
+
+// watchConfig polls inst.configPath for mtime changes and reloads it in
+// place. Changes are debounced so the existing write-then-rename ".bak"
+// backup (see serveConfig) doesn't get mistaken for two separate edits. If
+// the gateway is running when a reload lands, it's asked to re-read its
+// config via sendReloadSignal, falling back to a full restart where that
+// isn't supported (Windows, or if the signal can't be delivered).
+//
+// inst.done is closed when the instance is deleted, so this goroutine
+// doesn't outlive its instance or keep polling a config path nobody owns.
+func (inst *Instance) watchConfig() {
+	var lastMod time.Time
+	if fi, err := os.Stat(inst.configPath); err == nil {
+		lastMod = fi.ModTime()
+	}
+
+	for {
+		select {
+		case <-inst.done:
+			return
+		case <-time.After(configPollInterval):
+		}
+
+		fi, err := os.Stat(inst.configPath)
+		if err != nil || fi.ModTime().Equal(lastMod) {
+			continue
+		}
+
+		select {
+		case <-inst.done:
+			return
+		case <-time.After(configDebounce):
+		}
+		fi2, err := os.Stat(inst.configPath)
+		if err != nil || !fi2.ModTime().Equal(fi.ModTime()) {
+			continue // still being written; pick it up on a later tick
+		}
+		lastMod = fi2.ModTime()
+
+		cfg, err := readConfigMap(inst.configPath)
+		if err != nil {
+			inst.addLog("config changed on disk but failed to parse: %v", err)
+			continue
+		}
+		if gw, ok := cfg["gateway"].(map[string]interface{}); ok {
+			if p, ok := gw["port"].(float64); ok {
+				inst.mu.Lock()
+				inst.healthPort = int(p)
+				inst.mu.Unlock()
+			}
+		}
+		inst.addLog("config changed on disk, reloaded")
+
+		if !inst.isRunning() {
+			continue
+		}
+		if err := inst.sendReloadSignal(); err != nil {
+			inst.addLog("Restarting gateway to apply new config (%v)", err)
+			inst.stopGateway()
+			time.Sleep(1500 * time.Millisecond)
+			inst.mu.Lock()
+			inst.manualStop = false
+			inst.retryCount = 0
+			inst.mu.Unlock()
+			if err := inst.startGateway(); err != nil {
+				inst.addLog("Failed to restart after config reload: %v", err)
+			}
+		} else {
+			inst.addLog("Sent reload signal to gateway to apply new config")
+		}
+	}
+}
+
+func (inst *Instance) captureOutput(r io.Reader) {
 	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
-		d.addLog("[gateway] %s", scanner.Text())
+		inst.addLog("[gateway] %s", scanner.Text())
 	}
 }
 
-// ── Logging ─────────────────────────────────────────────
+// ── Instance: logging ───────────────────────────────────
 
-func (d *Dashboard) addLog(format string, args ...interface{}) {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-	d.addLogLocked(format, args...)
+func (inst *Instance) addLog(format string, args ...interface{}) {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	inst.addLogLocked(format, args...)
+}
+
+// collapseNewlines joins a multi-line message (e.g. the raw output of
+// `picoclaw onboard`) onto a single physical line, so one log entry is
+// always exactly one line — required for SSE framing in serveLogsStream and
+// for the per-line timestamp parsing in parseLineTime.
+func collapseNewlines(msg string) string {
+	msg = strings.ReplaceAll(msg, "\r\n", "\n")
+	return strings.ReplaceAll(msg, "\n", " \\n ")
 }
 
-func (d *Dashboard) addLogLocked(format string, args ...interface{}) {
+func (inst *Instance) addLogLocked(format string, args ...interface{}) {
 	ts := time.Now().Format("15:04:05")
-	line := fmt.Sprintf("[%s] %s", ts, fmt.Sprintf(format, args...))
-	d.logs = append(d.logs, line)
-	if len(d.logs) > maxLogLines {
-		d.logs = d.logs[len(d.logs)-maxLogLines:]
+	msg := collapseNewlines(fmt.Sprintf(format, args...))
+	line := fmt.Sprintf("[%s] %s", ts, msg)
+	inst.logs = append(inst.logs, line)
+	if len(inst.logs) > maxLogLines {
+		inst.logs = inst.logs[len(inst.logs)-maxLogLines:]
+	}
+	inst.broadcastLocked(line)
+
+	if strings.HasPrefix(msg, "[gateway] ") {
+		inst.gatewayLog.write(line)
+	} else {
+		inst.dashboardLog.write(line)
 	}
 }
 
-// ── Config ──────────────────────────────────────────────
+func (inst *Instance) logsSnapshot() []string {
+	inst.mu.RLock()
+	defer inst.mu.RUnlock()
+	logs := make([]string, len(inst.logs))
+	copy(logs, inst.logs)
+	return logs
+}
 
-func (d *Dashboard) readConfigMap() (map[string]interface{}, error) {
-	data, err := os.ReadFile(d.configPath)
-	if err != nil {
-		return nil, err
+// ── Instance: status & health ───────────────────────────
+
+func (inst *Instance) healthPortSnapshot() int {
+	inst.mu.RLock()
+	defer inst.mu.RUnlock()
+	return inst.healthPort
+}
+
+func (inst *Instance) status() StatusResponse {
+	inst.mu.RLock()
+	running := inst.running
+	var pid int
+	var uptime string
+	if running && inst.process != nil && inst.process.Process != nil {
+		pid = inst.process.Process.Pid
+		uptime = time.Since(inst.startTime).Truncate(time.Second).String()
 	}
-	var cfg map[string]interface{}
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return nil, err
+	state := inst.state
+	retryCount := inst.retryCount
+	var nextAttempt *time.Time
+	if state == StateBackoff && !inst.nextAttempt.IsZero() {
+		na := inst.nextAttempt
+		nextAttempt = &na
 	}
-	return cfg, nil
+	configPath := inst.configPath
+	binaryPath := inst.binaryPath
+	healthPort := inst.healthPort
+	inst.mu.RUnlock()
+
+	st := StatusResponse{
+		Name:           inst.name,
+		GatewayRunning: running,
+		GatewayPID:     pid,
+		Uptime:         uptime,
+		ConfigExists:   fileExists(configPath),
+		BinaryExists:   fileExists(binaryPath),
+		BinaryPath:     binaryPath,
+		ConfigPath:     configPath,
+		HealthPort:     healthPort,
+		State:          state,
+		RetryCount:     retryCount,
+		NextAttempt:    nextAttempt,
+	}
+
+	if running {
+		st.Health = inst.checkHealth()
+	}
+	return st
 }
 
-func (d *Dashboard) checkHealth() *HealthInfo {
+func (inst *Instance) checkHealth() *HealthInfo {
 	client := &http.Client{Timeout: 2 * time.Second}
-	resp, err := client.Get(fmt.Sprintf("http://localhost:%d/health", d.healthPort))
+	resp, err := client.Get(fmt.Sprintf("http://localhost:%d/health", inst.healthPort))
 	if err != nil {
 		return nil
 	}
@@ -437,6 +1175,20 @@ func (d *Dashboard) checkHealth() *HealthInfo {
 	return &h
 }
 
+// ── Config ──────────────────────────────────────────────
+
+func readConfigMap(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg map[string]interface{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func validateConfig(cfg map[string]interface{}) []string {
 	var w []string
 
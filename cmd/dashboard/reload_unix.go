@@ -0,0 +1,20 @@
+//go:build !windows
+
+package main
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// sendReloadSignal asks a running gateway to reload its config in place by
+// sending SIGHUP, the conventional "re-read config" signal on Unix.
+func (inst *Instance) sendReloadSignal() error {
+	inst.mu.RLock()
+	proc := inst.process
+	inst.mu.RUnlock()
+	if proc == nil || proc.Process == nil {
+		return fmt.Errorf("gateway is not running")
+	}
+	return proc.Process.Signal(syscall.SIGHUP)
+}
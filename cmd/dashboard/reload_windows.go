@@ -0,0 +1,11 @@
+//go:build windows
+
+package main
+
+import "fmt"
+
+// sendReloadSignal is a no-op on Windows, which has no SIGHUP equivalent;
+// the caller falls back to a full restart when this returns an error.
+func (inst *Instance) sendReloadSignal() error {
+	return fmt.Errorf("in-place config reload is not supported on windows")
+}